@@ -0,0 +1,472 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	schedulerlisters "k8s.io/kubernetes/pkg/scheduler/listers"
+	schedulernodeinfo "k8s.io/kubernetes/pkg/scheduler/nodeinfo"
+)
+
+// podOp describes a single pod mutation queued against a base NodeInfo that hasn't
+// been materialized into the overlay yet.
+type podOp struct {
+	add bool
+	pod *apiv1.Pod
+}
+
+// DeltaClusterSnapshot is an implementation of ClusterSnapshot optimized for typical
+// autoscaler usage - forking, making some changes, checking the result and reverting
+// the changes or another fork. Fork() is O(1), Revert() is O(1) and Commit() builds a
+// new base map over all nodes but copies only the NodeInfos touched in the forked
+// state, instead of BasicClusterSnapshot's Fork() which deep-copies the whole cluster
+// up front.
+//
+// Nodes that aren't modified after forking are served straight out of baseData, which
+// is not mutated while the snapshot is forked. Nodes that are modified get copied
+// into the overlay on first write. Pod additions/removals against nodes that haven't
+// been copied yet are queued in pendingPodOps and only applied once the node is
+// actually materialized, so that adding or removing a handful of pods doesn't force a
+// copy of every NodeInfo in the cluster.
+type DeltaClusterSnapshot struct {
+	baseData *internalBasicSnapshotData
+	overlay  *deltaSnapshotOverlay
+}
+
+type deltaSnapshotOverlay struct {
+	base *internalBasicSnapshotData
+
+	// nodeInfoOverlay holds nodes that have already been copied out of base (or were
+	// added after forking) and fully reflect any queued pendingPodOps.
+	nodeInfoOverlay map[string]*schedulernodeinfo.NodeInfo
+	// deletedNodes tombstones node names removed from base after forking.
+	deletedNodes map[string]bool
+	// pendingPodOps holds pod adds/removes against base nodes that haven't been
+	// copied into nodeInfoOverlay yet.
+	pendingPodOps map[string][]podOp
+}
+
+func newDeltaSnapshotOverlay(base *internalBasicSnapshotData) *deltaSnapshotOverlay {
+	return &deltaSnapshotOverlay{
+		base:            base,
+		nodeInfoOverlay: make(map[string]*schedulernodeinfo.NodeInfo),
+		deletedNodes:    make(map[string]bool),
+		pendingPodOps:   make(map[string][]podOp),
+	}
+}
+
+// materialize returns the overlay copy of nodeName, creating one (by cloning the base
+// NodeInfo and applying any pending pod ops) if one doesn't exist yet.
+func (o *deltaSnapshotOverlay) materialize(nodeName string) (*schedulernodeinfo.NodeInfo, error) {
+	if nodeInfo, found := o.nodeInfoOverlay[nodeName]; found {
+		return nodeInfo, nil
+	}
+	if o.deletedNodes[nodeName] {
+		return nil, fmt.Errorf("node %s not in snapshot", nodeName)
+	}
+	baseNodeInfo, found := o.base.nodeInfoMap[nodeName]
+	if !found {
+		return nil, fmt.Errorf("node %s not in snapshot", nodeName)
+	}
+	nodeInfo := baseNodeInfo.Clone()
+	for _, op := range o.pendingPodOps[nodeName] {
+		if op.add {
+			nodeInfo.AddPod(op.pod)
+		} else if err := nodeInfo.RemovePod(op.pod); err != nil {
+			return nil, fmt.Errorf("cannot replay queued pod removal; %v", err)
+		}
+	}
+	delete(o.pendingPodOps, nodeName)
+	o.nodeInfoOverlay[nodeName] = nodeInfo
+	return nodeInfo, nil
+}
+
+// get returns the current NodeInfo for nodeName, preferring the overlay but falling
+// back to the (immutable) base. A base node with pending pod ops is materialized
+// (copied into the overlay) first; otherwise no copy is made.
+func (o *deltaSnapshotOverlay) get(nodeName string) (*schedulernodeinfo.NodeInfo, error) {
+	if nodeInfo, found := o.nodeInfoOverlay[nodeName]; found {
+		return nodeInfo, nil
+	}
+	if o.deletedNodes[nodeName] {
+		return nil, fmt.Errorf("node %s not in snapshot", nodeName)
+	}
+	if len(o.pendingPodOps[nodeName]) > 0 {
+		return o.materialize(nodeName)
+	}
+	if nodeInfo, found := o.base.nodeInfoMap[nodeName]; found {
+		return nodeInfo, nil
+	}
+	return nil, fmt.Errorf("node %s not in snapshot", nodeName)
+}
+
+// view returns a NodeInfo reflecting nodeName's current state, applying any pending
+// pod ops to a throwaway clone of the base NodeInfo rather than promoting it into
+// nodeInfoOverlay. Used by read-only, whole-cluster walks (list, findPod) so that
+// merely listing pods/nodes doesn't force every pending-op node to pay a permanent
+// Clone() - only get() materializes and caches on purpose, when a node is actually
+// being read as a whole (e.g. by a scheduler predicate) rather than just walked.
+func (o *deltaSnapshotOverlay) view(nodeName string) (*schedulernodeinfo.NodeInfo, error) {
+	if nodeInfo, found := o.nodeInfoOverlay[nodeName]; found {
+		return nodeInfo, nil
+	}
+	if o.deletedNodes[nodeName] {
+		return nil, fmt.Errorf("node %s not in snapshot", nodeName)
+	}
+	baseNodeInfo, found := o.base.nodeInfoMap[nodeName]
+	if !found {
+		return nil, fmt.Errorf("node %s not in snapshot", nodeName)
+	}
+	ops := o.pendingPodOps[nodeName]
+	if len(ops) == 0 {
+		return baseNodeInfo, nil
+	}
+	nodeInfo := baseNodeInfo.Clone()
+	for _, op := range ops {
+		if op.add {
+			nodeInfo.AddPod(op.pod)
+		} else if err := nodeInfo.RemovePod(op.pod); err != nil {
+			return nil, fmt.Errorf("cannot replay queued pod removal; %v", err)
+		}
+	}
+	return nodeInfo, nil
+}
+
+func (o *deltaSnapshotOverlay) list() ([]*schedulernodeinfo.NodeInfo, error) {
+	nodeInfoList := make([]*schedulernodeinfo.NodeInfo, 0, len(o.base.nodeInfoMap)+len(o.nodeInfoOverlay))
+	seen := make(map[string]bool, len(o.nodeInfoOverlay))
+	for name, nodeInfo := range o.nodeInfoOverlay {
+		nodeInfoList = append(nodeInfoList, nodeInfo)
+		seen[name] = true
+	}
+	for name := range o.base.nodeInfoMap {
+		if seen[name] || o.deletedNodes[name] {
+			continue
+		}
+		nodeInfo, err := o.view(name)
+		if err != nil {
+			return nil, err
+		}
+		nodeInfoList = append(nodeInfoList, nodeInfo)
+	}
+	return nodeInfoList, nil
+}
+
+func (o *deltaSnapshotOverlay) addNode(node *apiv1.Node) error {
+	if o.deletedNodes[node.Name] {
+		delete(o.deletedNodes, node.Name)
+	} else if _, found := o.nodeInfoOverlay[node.Name]; found {
+		return fmt.Errorf("node %s already in snapshot", node.Name)
+	} else if _, found := o.base.nodeInfoMap[node.Name]; found {
+		return fmt.Errorf("node %s already in snapshot", node.Name)
+	}
+	nodeInfo := schedulernodeinfo.NewNodeInfo()
+	if err := nodeInfo.SetNode(node); err != nil {
+		return fmt.Errorf("cannot set node in NodeInfo; %v", err)
+	}
+	o.nodeInfoOverlay[node.Name] = nodeInfo
+	delete(o.pendingPodOps, node.Name)
+	return nil
+}
+
+func (o *deltaSnapshotOverlay) removeNode(nodeName string) error {
+	if _, err := o.get(nodeName); err != nil {
+		return err
+	}
+	delete(o.nodeInfoOverlay, nodeName)
+	delete(o.pendingPodOps, nodeName)
+	o.deletedNodes[nodeName] = true
+	return nil
+}
+
+func (o *deltaSnapshotOverlay) addPod(pod *apiv1.Pod, nodeName string) error {
+	if nodeInfo, found := o.nodeInfoOverlay[nodeName]; found {
+		nodeInfo.AddPod(pod)
+		return nil
+	}
+	// Check existence against deletedNodes/base directly rather than through get(),
+	// which would materialize (Clone()) the node as soon as it already had a pending
+	// op queued - defeating the batching pendingPodOps exists for.
+	if o.deletedNodes[nodeName] {
+		return fmt.Errorf("node %s not in snapshot", nodeName)
+	}
+	if _, found := o.base.nodeInfoMap[nodeName]; !found {
+		return fmt.Errorf("node %s not in snapshot", nodeName)
+	}
+	o.pendingPodOps[nodeName] = append(o.pendingPodOps[nodeName], podOp{add: true, pod: pod})
+	return nil
+}
+
+func (o *deltaSnapshotOverlay) removePod(namespace, podName string) error {
+	nodeName, pod, err := o.findPod(namespace, podName)
+	if err != nil {
+		return err
+	}
+	if nodeInfo, found := o.nodeInfoOverlay[nodeName]; found {
+		if err := nodeInfo.RemovePod(pod); err != nil {
+			return fmt.Errorf("cannot remove pod; %v", err)
+		}
+		return nil
+	}
+	o.pendingPodOps[nodeName] = append(o.pendingPodOps[nodeName], podOp{add: false, pod: pod})
+	return nil
+}
+
+func (o *deltaSnapshotOverlay) findPod(namespace, podName string) (string, *apiv1.Pod, error) {
+	nodeInfoList, err := o.list()
+	if err != nil {
+		return "", nil, err
+	}
+	for _, nodeInfo := range nodeInfoList {
+		for _, pod := range nodeInfo.Pods() {
+			if pod.Namespace == namespace && pod.Name == podName {
+				return nodeInfo.Node().Name, pod, nil
+			}
+		}
+	}
+	return "", nil, fmt.Errorf("pod %s/%s not in snapshot", namespace, podName)
+}
+
+func (o *deltaSnapshotOverlay) getAllPods() ([]*apiv1.Pod, error) {
+	nodeInfoList, err := o.list()
+	if err != nil {
+		return nil, err
+	}
+	var pods []*apiv1.Pod
+	for _, nodeInfo := range nodeInfoList {
+		pods = append(pods, nodeInfo.Pods()...)
+	}
+	return pods, nil
+}
+
+func (o *deltaSnapshotOverlay) getAllNodes() ([]*apiv1.Node, error) {
+	nodeInfoList, err := o.list()
+	if err != nil {
+		return nil, err
+	}
+	var nodes []*apiv1.Node
+	for _, nodeInfo := range nodeInfoList {
+		nodes = append(nodes, nodeInfo.Node())
+	}
+	return nodes, nil
+}
+
+// commit folds the overlay into a new, flattened base. Nodes touched in the overlay
+// are merged in, tombstoned nodes are dropped, and any still-pending pod ops are
+// materialized so the new base never carries unresolved deltas.
+func (o *deltaSnapshotOverlay) commit() (*internalBasicSnapshotData, error) {
+	nodeInfoList, err := o.list()
+	if err != nil {
+		return nil, err
+	}
+	nodeInfoMap := make(map[string]*schedulernodeinfo.NodeInfo, len(nodeInfoList))
+	for _, nodeInfo := range nodeInfoList {
+		nodeInfoMap[nodeInfo.Node().Name] = nodeInfo
+	}
+	return &internalBasicSnapshotData{nodeInfoMap: nodeInfoMap}, nil
+}
+
+type deltaSnapshotNodeLister deltaSnapshotOverlay
+type deltaSnapshotPodLister deltaSnapshotOverlay
+
+func (o *deltaSnapshotOverlay) NodeInfos() schedulerlisters.NodeInfoLister {
+	return (*deltaSnapshotNodeLister)(o)
+}
+
+func (o *deltaSnapshotOverlay) Pods() schedulerlisters.PodLister {
+	return (*deltaSnapshotPodLister)(o)
+}
+
+func (lister *deltaSnapshotNodeLister) List() ([]*schedulernodeinfo.NodeInfo, error) {
+	return (*deltaSnapshotOverlay)(lister).list()
+}
+
+func (lister *deltaSnapshotNodeLister) HavePodsWithAffinityList() ([]*schedulernodeinfo.NodeInfo, error) {
+	nodeInfoList, err := (*deltaSnapshotOverlay)(lister).list()
+	if err != nil {
+		return nil, err
+	}
+	havePodsWithAffinityList := make([]*schedulernodeinfo.NodeInfo, 0, len(nodeInfoList))
+	for _, nodeInfo := range nodeInfoList {
+		if len(nodeInfo.PodsWithAffinity()) > 0 {
+			havePodsWithAffinityList = append(havePodsWithAffinityList, nodeInfo)
+		}
+	}
+	return havePodsWithAffinityList, nil
+}
+
+func (lister *deltaSnapshotNodeLister) Get(nodeName string) (*schedulernodeinfo.NodeInfo, error) {
+	return (*deltaSnapshotOverlay)(lister).get(nodeName)
+}
+
+func (lister *deltaSnapshotPodLister) List(selector labels.Selector) ([]*apiv1.Pod, error) {
+	alwaysTrue := func(p *apiv1.Pod) bool { return true }
+	return lister.FilteredList(alwaysTrue, selector)
+}
+
+func (lister *deltaSnapshotPodLister) FilteredList(podFilter schedulerlisters.PodFilter, selector labels.Selector) ([]*apiv1.Pod, error) {
+	nodeInfoList, err := (*deltaSnapshotOverlay)(lister).list()
+	if err != nil {
+		return nil, err
+	}
+	pods := make([]*apiv1.Pod, 0)
+	for _, nodeInfo := range nodeInfoList {
+		for _, pod := range nodeInfo.Pods() {
+			if podFilter(pod) && selector.Matches(labels.Set(pod.Labels)) {
+				pods = append(pods, pod)
+			}
+		}
+	}
+	return pods, nil
+}
+
+// NewDeltaClusterSnapshot creates an instance of DeltaClusterSnapshot.
+func NewDeltaClusterSnapshot() *DeltaClusterSnapshot {
+	snapshot := &DeltaClusterSnapshot{}
+	_ = snapshot.Clear()
+	return snapshot
+}
+
+func (snapshot *DeltaClusterSnapshot) getInternalData() interface {
+	schedulerlisters.SharedLister
+	addNode(node *apiv1.Node) error
+	removeNode(nodeName string) error
+	addPod(pod *apiv1.Pod, nodeName string) error
+	removePod(namespace, podName string) error
+	getAllPods() ([]*apiv1.Pod, error)
+	getAllNodes() ([]*apiv1.Node, error)
+} {
+	if snapshot.overlay != nil {
+		return snapshot.overlay
+	}
+	return (*internalBasicSnapshotDataOps)(snapshot.baseData)
+}
+
+// AddNode adds node to the snapshot.
+func (snapshot *DeltaClusterSnapshot) AddNode(node *apiv1.Node) error {
+	return snapshot.getInternalData().addNode(node)
+}
+
+// RemoveNode removes nodes (and pods scheduled to it) from the snapshot.
+func (snapshot *DeltaClusterSnapshot) RemoveNode(nodeName string) error {
+	return snapshot.getInternalData().removeNode(nodeName)
+}
+
+// AddPod adds pod to the snapshot and schedules it to given node.
+func (snapshot *DeltaClusterSnapshot) AddPod(pod *apiv1.Pod, nodeName string) error {
+	return snapshot.getInternalData().addPod(pod, nodeName)
+}
+
+// RemovePod removes pod from the snapshot.
+func (snapshot *DeltaClusterSnapshot) RemovePod(namespace string, podName string) error {
+	return snapshot.getInternalData().removePod(namespace, podName)
+}
+
+// GetAllPods returns list of all the pods in snapshot
+func (snapshot *DeltaClusterSnapshot) GetAllPods() ([]*apiv1.Pod, error) {
+	return snapshot.getInternalData().getAllPods()
+}
+
+// GetAllNodes returns list of ll the nodes in snapshot
+func (snapshot *DeltaClusterSnapshot) GetAllNodes() ([]*apiv1.Node, error) {
+	return snapshot.getInternalData().getAllNodes()
+}
+
+// Fork creates a fork of snapshot state. All modifications can later be reverted to
+// moment of forking via Revert(). Unlike BasicClusterSnapshot, this is O(1): baseData
+// is left untouched and all forked mutations are recorded in a new, empty overlay.
+// Forking already forked snapshot is not allowed and will result with an error.
+func (snapshot *DeltaClusterSnapshot) Fork() error {
+	if snapshot.overlay != nil {
+		return fmt.Errorf("snapshot already forked")
+	}
+	snapshot.overlay = newDeltaSnapshotOverlay(snapshot.baseData)
+	return nil
+}
+
+// Revert reverts snapshot state to moment of forking by dropping the overlay. O(1).
+func (snapshot *DeltaClusterSnapshot) Revert() error {
+	snapshot.overlay = nil
+	return nil
+}
+
+// Commit commits changes done after forking by folding the overlay into a new base.
+func (snapshot *DeltaClusterSnapshot) Commit() error {
+	if snapshot.overlay == nil {
+		// do nothing
+		return nil
+	}
+	newBaseData, err := snapshot.overlay.commit()
+	if err != nil {
+		return err
+	}
+	snapshot.baseData = newBaseData
+	snapshot.overlay = nil
+	return nil
+}
+
+// Clear reset cluster snapshot to empty, unforked state
+func (snapshot *DeltaClusterSnapshot) Clear() error {
+	snapshot.baseData = newInternalBasicSnapshotData()
+	snapshot.overlay = nil
+	return nil
+}
+
+// GetSchedulerLister exposes snapshot state as scheduler's SharedLister.
+func (snapshot *DeltaClusterSnapshot) GetSchedulerLister() (schedulerlisters.SharedLister, error) {
+	return snapshot.getInternalData(), nil
+}
+
+// internalBasicSnapshotDataOps adapts internalBasicSnapshotData to the same
+// unforked-state operations the overlay exposes, so DeltaClusterSnapshot can treat
+// forked and unforked state uniformly.
+type internalBasicSnapshotDataOps internalBasicSnapshotData
+
+func (data *internalBasicSnapshotDataOps) addNode(node *apiv1.Node) error {
+	return (*internalBasicSnapshotData)(data).addNode(node)
+}
+
+func (data *internalBasicSnapshotDataOps) removeNode(nodeName string) error {
+	return (*internalBasicSnapshotData)(data).removeNode(nodeName)
+}
+
+func (data *internalBasicSnapshotDataOps) addPod(pod *apiv1.Pod, nodeName string) error {
+	return (*internalBasicSnapshotData)(data).addPod(pod, nodeName)
+}
+
+func (data *internalBasicSnapshotDataOps) removePod(namespace, podName string) error {
+	return (*internalBasicSnapshotData)(data).removePod(namespace, podName)
+}
+
+func (data *internalBasicSnapshotDataOps) getAllPods() ([]*apiv1.Pod, error) {
+	return (*internalBasicSnapshotData)(data).getAllPods()
+}
+
+func (data *internalBasicSnapshotDataOps) getAllNodes() ([]*apiv1.Node, error) {
+	return (*internalBasicSnapshotData)(data).getAllNodes()
+}
+
+func (data *internalBasicSnapshotDataOps) Pods() schedulerlisters.PodLister {
+	return (*internalBasicSnapshotData)(data).Pods()
+}
+
+func (data *internalBasicSnapshotDataOps) NodeInfos() schedulerlisters.NodeInfoLister {
+	return (*internalBasicSnapshotData)(data).NodeInfos()
+}
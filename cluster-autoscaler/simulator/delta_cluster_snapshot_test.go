@@ -0,0 +1,139 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func testNode(name string) *apiv1.Node {
+	return &apiv1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name)}}
+}
+
+func testPod(namespace, name, nodeName string) *apiv1.Pod {
+	return &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID(namespace + "/" + name)},
+		Spec:       apiv1.PodSpec{NodeName: nodeName},
+	}
+}
+
+func nodeNames(t *testing.T, snapshot ClusterSnapshot) []string {
+	t.Helper()
+	nodes, err := snapshot.GetAllNodes()
+	assert.NoError(t, err)
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	return names
+}
+
+func TestDeltaClusterSnapshotForkCommitRevert(t *testing.T) {
+	snapshot := NewDeltaClusterSnapshot()
+	assert.NoError(t, snapshot.AddNode(testNode("n1")))
+	assert.NoError(t, snapshot.AddPod(testPod("default", "p1", "n1"), "n1"))
+
+	assert.NoError(t, snapshot.Fork())
+	assert.NoError(t, snapshot.AddNode(testNode("n2")))
+	assert.NoError(t, snapshot.AddPod(testPod("default", "p2", "n2"), "n2"))
+	assert.ElementsMatch(t, []string{"n1", "n2"}, nodeNames(t, snapshot))
+
+	assert.NoError(t, snapshot.Revert())
+	assert.ElementsMatch(t, []string{"n1"}, nodeNames(t, snapshot))
+	pods, err := snapshot.GetAllPods()
+	assert.NoError(t, err)
+	assert.Len(t, pods, 1)
+
+	assert.NoError(t, snapshot.Fork())
+	assert.NoError(t, snapshot.AddNode(testNode("n2")))
+	assert.NoError(t, snapshot.Commit())
+	assert.Nil(t, snapshot.overlay)
+	assert.ElementsMatch(t, []string{"n1", "n2"}, nodeNames(t, snapshot))
+
+	// A forked node should be reverted too, after being committed once.
+	assert.NoError(t, snapshot.Fork())
+	assert.NoError(t, snapshot.RemoveNode("n2"))
+	assert.NoError(t, snapshot.Revert())
+	assert.ElementsMatch(t, []string{"n1", "n2"}, nodeNames(t, snapshot))
+}
+
+func TestDeltaClusterSnapshotTombstoneAndReAdd(t *testing.T) {
+	snapshot := NewDeltaClusterSnapshot()
+	assert.NoError(t, snapshot.AddNode(testNode("n1")))
+	assert.NoError(t, snapshot.Fork())
+
+	assert.NoError(t, snapshot.RemoveNode("n1"))
+	_, err := snapshot.GetSchedulerLister()
+	assert.NoError(t, err)
+	lister, err := snapshot.GetSchedulerLister()
+	assert.NoError(t, err)
+	_, err = lister.NodeInfos().Get("n1")
+	assert.Error(t, err, "tombstoned node should not be visible")
+
+	// Re-adding a tombstoned node within the same fork should resurrect it, not
+	// error out as "already in snapshot".
+	assert.NoError(t, snapshot.AddNode(testNode("n1")))
+	assert.NoError(t, snapshot.AddPod(testPod("default", "p1", "n1"), "n1"))
+	_, err = lister.NodeInfos().Get("n1")
+	assert.NoError(t, err)
+}
+
+func TestDeltaClusterSnapshotPendingPodOpsReplayOnMaterialize(t *testing.T) {
+	snapshot := NewDeltaClusterSnapshot()
+	assert.NoError(t, snapshot.AddNode(testNode("n1")))
+	assert.NoError(t, snapshot.AddPod(testPod("default", "p1", "n1"), "n1"))
+	assert.NoError(t, snapshot.Fork())
+
+	// Queue adds/removes against n1 without anything reading it as a whole yet, so
+	// they should sit in pendingPodOps rather than materializing the node.
+	assert.NoError(t, snapshot.AddPod(testPod("default", "p2", "n1"), "n1"))
+	assert.NoError(t, snapshot.RemovePod("default", "p1"))
+	_, found := snapshot.overlay.nodeInfoOverlay["n1"]
+	assert.False(t, found, "addPod/removePod must not materialize the node on their own")
+
+	// Reading the node as a whole (get()) must replay the queued ops faithfully.
+	lister, err := snapshot.GetSchedulerLister()
+	assert.NoError(t, err)
+	nodeInfo, err := lister.NodeInfos().Get("n1")
+	assert.NoError(t, err)
+	podNames := make([]string, 0, len(nodeInfo.Pods()))
+	for _, pod := range nodeInfo.Pods() {
+		podNames = append(podNames, pod.Name)
+	}
+	assert.ElementsMatch(t, []string{"p2"}, podNames)
+}
+
+func TestDeltaClusterSnapshotListViewMatchesMaterialize(t *testing.T) {
+	snapshot := NewDeltaClusterSnapshot()
+	assert.NoError(t, snapshot.AddNode(testNode("n1")))
+	assert.NoError(t, snapshot.AddPod(testPod("default", "p1", "n1"), "n1"))
+	assert.NoError(t, snapshot.Fork())
+	assert.NoError(t, snapshot.AddPod(testPod("default", "p2", "n1"), "n1"))
+
+	// list() (used by GetAllPods/GetAllNodes) must reflect pending ops via view(), not
+	// just whatever has already been materialized into the overlay.
+	pods, err := snapshot.GetAllPods()
+	assert.NoError(t, err)
+	assert.Len(t, pods, 2)
+	_, found := snapshot.overlay.nodeInfoOverlay["n1"]
+	assert.False(t, found, "list() must not materialize nodes it only reads through view()")
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"sync"
+	"time"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+)
+
+// SnapshotSyncer keeps a ClusterSnapshot in sync with the live cluster by watching
+// nodes and pods via shared informers and applying the resulting events to the
+// snapshot as they arrive. It removes the need for callers to hand-drive
+// AddNode/AddPod/RemoveNode/RemovePod to mirror cluster state themselves.
+//
+// All mutations are taken under a mutex, so it's safe for a caller to Fork() the
+// snapshot, run scheduling simulations against it and Revert() without racing
+// informer updates - as long as the caller does so while holding the same mutex via
+// Mutex(), or from within a hook registered with OnResync(), which Run invokes with
+// the mutex held.
+type SnapshotSyncer struct {
+	mutex    sync.Mutex
+	snapshot ClusterSnapshot
+
+	nodeInformer cache.SharedIndexInformer
+	podInformer  cache.SharedIndexInformer
+
+	resyncPeriod time.Duration
+	onResync     []func()
+}
+
+// NewSnapshotSyncer creates a SnapshotSyncer that hydrates snapshot from client's node
+// and pod informers.
+func NewSnapshotSyncer(client kubernetes.Interface, snapshot ClusterSnapshot, resyncPeriod time.Duration) *SnapshotSyncer {
+	factory := informers.NewSharedInformerFactory(client, resyncPeriod)
+	syncer := &SnapshotSyncer{
+		snapshot:     snapshot,
+		nodeInformer: factory.Core().V1().Nodes().Informer(),
+		podInformer:  factory.Core().V1().Pods().Informer(),
+		resyncPeriod: resyncPeriod,
+	}
+
+	syncer.nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    syncer.addNode,
+		UpdateFunc: syncer.updateNode,
+		DeleteFunc: syncer.deleteNode,
+	})
+	syncer.podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    syncer.addPod,
+		UpdateFunc: syncer.updatePod,
+		DeleteFunc: syncer.deletePod,
+	})
+
+	return syncer
+}
+
+// Mutex returns the mutex guarding snapshot mutations, so callers can take it while
+// forking, simulating against and reverting the snapshot without racing informer
+// updates.
+func (s *SnapshotSyncer) Mutex() *sync.Mutex {
+	return &s.mutex
+}
+
+// OnResync registers a hook that Run invokes, with the snapshot mutex held, every
+// resyncPeriod once the informer caches have synced. Hooks are not invoked when
+// resyncPeriod is 0 or negative. Autoscaler loops can use this to
+// Fork() a consistent snapshot, run their simulations and Revert() it.
+func (s *SnapshotSyncer) OnResync(fn func()) {
+	s.onResync = append(s.onResync, fn)
+}
+
+// Run starts the node and pod informers, and - once their caches have synced - a
+// ticker that fires the registered OnResync hooks every resyncPeriod. It blocks until
+// stopCh is closed.
+func (s *SnapshotSyncer) Run(stopCh <-chan struct{}) {
+	go s.nodeInformer.Run(stopCh)
+	go s.podInformer.Run(stopCh)
+
+	if !s.WaitForCacheSync(stopCh) {
+		return
+	}
+
+	// A resyncPeriod of 0 is the documented way to tell the informer factory "don't
+	// periodically resync" - honor the same convention here instead of handing it to
+	// time.NewTicker, which panics on a non-positive interval.
+	if s.resyncPeriod <= 0 {
+		<-stopCh
+		return
+	}
+
+	ticker := time.NewTicker(s.resyncPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+			s.runOnResync()
+		}
+	}
+}
+
+func (s *SnapshotSyncer) runOnResync() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, fn := range s.onResync {
+		fn()
+	}
+}
+
+// WaitForCacheSync blocks until the node and pod informer caches have done their
+// initial sync, or stopCh is closed. It returns false in the latter case - callers
+// must not treat the snapshot as usable until this returns true.
+func (s *SnapshotSyncer) WaitForCacheSync(stopCh <-chan struct{}) bool {
+	return cache.WaitForCacheSync(stopCh, s.nodeInformer.HasSynced, s.podInformer.HasSynced)
+}
+
+func (s *SnapshotSyncer) addNode(obj interface{}) {
+	node, ok := obj.(*apiv1.Node)
+	if !ok {
+		klog.Errorf("snapshot syncer: expected *v1.Node, got %T", obj)
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.snapshot.AddNode(node); err != nil {
+		klog.Errorf("snapshot syncer: failed to add node %s: %v", node.Name, err)
+	}
+}
+
+func (s *SnapshotSyncer) updateNode(oldObj, newObj interface{}) {
+	oldNode, ok := oldObj.(*apiv1.Node)
+	if !ok {
+		klog.Errorf("snapshot syncer: expected *v1.Node, got %T", oldObj)
+		return
+	}
+	newNode, ok := newObj.(*apiv1.Node)
+	if !ok {
+		klog.Errorf("snapshot syncer: expected *v1.Node, got %T", newObj)
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// ClusterSnapshot has no in-place node update, and RemoveNode() drops every pod
+	// scheduled to the node along with it - so the pods have to be salvaged from the
+	// old NodeInfo and re-added once the new one is in, or a Node update (which
+	// kubelet sends on every heartbeat) would empty the node's pod list until some
+	// unrelated pod event happens to repopulate it.
+	var pods []*apiv1.Pod
+	if lister, err := s.snapshot.GetSchedulerLister(); err == nil {
+		if nodeInfo, err := lister.NodeInfos().Get(oldNode.Name); err == nil {
+			pods = nodeInfo.Pods()
+		}
+	}
+
+	if err := s.snapshot.RemoveNode(oldNode.Name); err != nil {
+		klog.Errorf("snapshot syncer: failed to remove stale node %s: %v", oldNode.Name, err)
+		return
+	}
+	if err := s.snapshot.AddNode(newNode); err != nil {
+		klog.Errorf("snapshot syncer: failed to add updated node %s: %v", newNode.Name, err)
+		return
+	}
+	for _, pod := range pods {
+		if err := s.snapshot.AddPod(pod, newNode.Name); err != nil {
+			klog.Errorf("snapshot syncer: failed to re-add pod %s/%s to updated node %s: %v", pod.Namespace, pod.Name, newNode.Name, err)
+		}
+	}
+}
+
+func (s *SnapshotSyncer) deleteNode(obj interface{}) {
+	node, ok := obj.(*apiv1.Node)
+	if !ok {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			s.deleteNodeByName(tombstone.Key)
+			return
+		}
+		klog.Errorf("snapshot syncer: expected *v1.Node, got %T", obj)
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.snapshot.RemoveNode(node.Name); err != nil {
+		klog.Errorf("snapshot syncer: failed to remove node %s: %v", node.Name, err)
+	}
+}
+
+func (s *SnapshotSyncer) deleteNodeByName(name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.snapshot.RemoveNode(name); err != nil {
+		klog.Errorf("snapshot syncer: failed to remove node %s: %v", name, err)
+	}
+}
+
+func (s *SnapshotSyncer) addPod(obj interface{}) {
+	pod, ok := podFromObj(obj)
+	if !ok {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.addPodLocked(pod)
+}
+
+// updatePod removes the pod's old state and re-adds its new state as a single critical
+// section. Taking the mutex separately for the remove and the add would let a
+// concurrent OnResync hook observe the pod as absent from its node in between - the
+// consistent-snapshot guarantee the type's doc comment promises.
+func (s *SnapshotSyncer) updatePod(oldObj, newObj interface{}) {
+	oldPod, ok := podFromObj(oldObj)
+	if !ok {
+		return
+	}
+	newPod, ok := podFromObj(newObj)
+	if !ok {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.removePodLocked(oldPod)
+	s.addPodLocked(newPod)
+}
+
+func (s *SnapshotSyncer) deletePod(obj interface{}) {
+	pod, ok := podFromObj(obj)
+	if !ok {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.removePodLocked(pod)
+}
+
+// addPodLocked adds pod to the snapshot. Callers must hold s.mutex.
+func (s *SnapshotSyncer) addPodLocked(pod *apiv1.Pod) {
+	if pod.Spec.NodeName == "" {
+		return
+	}
+	if err := s.snapshot.AddPod(pod, pod.Spec.NodeName); err != nil {
+		klog.Errorf("snapshot syncer: failed to add pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+}
+
+// removePodLocked removes pod from the snapshot. Callers must hold s.mutex.
+func (s *SnapshotSyncer) removePodLocked(pod *apiv1.Pod) {
+	if pod.Spec.NodeName == "" {
+		return
+	}
+	if err := s.snapshot.RemovePod(pod.Namespace, pod.Name); err != nil {
+		klog.Errorf("snapshot syncer: failed to remove pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+}
+
+// podFromObj extracts a *v1.Pod from an informer event object, unwrapping
+// DeletedFinalStateUnknown tombstones, and logs (returning ok=false) if obj isn't a pod.
+func podFromObj(obj interface{}) (*apiv1.Pod, bool) {
+	if pod, ok := obj.(*apiv1.Pod); ok {
+		return pod, true
+	}
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		if pod, ok := tombstone.Obj.(*apiv1.Pod); ok {
+			return pod, true
+		}
+		klog.Errorf("snapshot syncer: expected *v1.Pod in tombstone, got %T", tombstone.Obj)
+		return nil, false
+	}
+	klog.Errorf("snapshot syncer: expected *v1.Pod, got %T", obj)
+	return nil, false
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newTestSyncer(snapshot ClusterSnapshot) *SnapshotSyncer {
+	return &SnapshotSyncer{snapshot: snapshot}
+}
+
+func podNamesOnNode(t *testing.T, snapshot ClusterSnapshot, nodeName string) []string {
+	t.Helper()
+	lister, err := snapshot.GetSchedulerLister()
+	assert.NoError(t, err)
+	nodeInfo, err := lister.NodeInfos().Get(nodeName)
+	assert.NoError(t, err)
+	names := make([]string, 0, len(nodeInfo.Pods()))
+	for _, pod := range nodeInfo.Pods() {
+		names = append(names, pod.Name)
+	}
+	return names
+}
+
+func TestSnapshotSyncerAddDeleteNode(t *testing.T) {
+	snapshot := NewBasicClusterSnapshot()
+	syncer := newTestSyncer(snapshot)
+
+	syncer.addNode(testNode("n1"))
+	assert.ElementsMatch(t, []string{"n1"}, nodeNames(t, snapshot))
+
+	syncer.deleteNode(testNode("n1"))
+	assert.Empty(t, nodeNames(t, snapshot))
+}
+
+func TestSnapshotSyncerDeleteNodeTombstone(t *testing.T) {
+	snapshot := NewBasicClusterSnapshot()
+	syncer := newTestSyncer(snapshot)
+	syncer.addNode(testNode("n1"))
+
+	syncer.deleteNode(cache.DeletedFinalStateUnknown{Key: "n1", Obj: testNode("n1")})
+	assert.Empty(t, nodeNames(t, snapshot))
+}
+
+func TestSnapshotSyncerUpdateNodePreservesPods(t *testing.T) {
+	snapshot := NewBasicClusterSnapshot()
+	syncer := newTestSyncer(snapshot)
+	oldNode := testNode("n1")
+	syncer.addNode(oldNode)
+	syncer.addPod(testPod("default", "p1", "n1"))
+
+	newNode := testNode("n1")
+	newNode.Labels = map[string]string{"updated": "true"}
+	syncer.updateNode(oldNode, newNode)
+
+	assert.ElementsMatch(t, []string{"p1"}, podNamesOnNode(t, snapshot, "n1"))
+}
+
+func TestSnapshotSyncerAddUpdateDeletePod(t *testing.T) {
+	snapshot := NewBasicClusterSnapshot()
+	syncer := newTestSyncer(snapshot)
+	syncer.addNode(testNode("n1"))
+
+	syncer.addPod(testPod("default", "p1", "n1"))
+	assert.ElementsMatch(t, []string{"p1"}, podNamesOnNode(t, snapshot, "n1"))
+
+	oldPod := testPod("default", "p1", "n1")
+	newPod := testPod("default", "p1", "n1")
+	newPod.Labels = map[string]string{"updated": "true"}
+	syncer.updatePod(oldPod, newPod)
+	assert.ElementsMatch(t, []string{"p1"}, podNamesOnNode(t, snapshot, "n1"))
+
+	syncer.deletePod(newPod)
+	assert.Empty(t, podNamesOnNode(t, snapshot, "n1"))
+}
+
+func TestSnapshotSyncerDeletePodTombstone(t *testing.T) {
+	snapshot := NewBasicClusterSnapshot()
+	syncer := newTestSyncer(snapshot)
+	syncer.addNode(testNode("n1"))
+	pod := testPod("default", "p1", "n1")
+	syncer.addPod(pod)
+
+	syncer.deletePod(cache.DeletedFinalStateUnknown{Key: "default/p1", Obj: pod})
+	assert.Empty(t, podNamesOnNode(t, snapshot, "n1"))
+}
+
+func TestSnapshotSyncerRunHandlesZeroResyncPeriod(t *testing.T) {
+	syncer := NewSnapshotSyncer(fake.NewSimpleClientset(), NewBasicClusterSnapshot(), 0)
+	stopCh := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		assert.NotPanics(t, func() { syncer.Run(stopCh) })
+		close(done)
+	}()
+
+	// Give the informers a chance to sync before stopping, so Run reaches the code
+	// past WaitForCacheSync (and the resyncPeriod<=0 guard) instead of returning early
+	// because stopCh was already closed.
+	time.Sleep(100 * time.Millisecond)
+	close(stopCh)
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return after stopCh was closed")
+	}
+}
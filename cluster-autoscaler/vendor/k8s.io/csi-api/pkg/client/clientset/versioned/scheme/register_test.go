@@ -0,0 +1,52 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	storagev1 "k8s.io/api/storage/v1"
+	storagev1beta1 "k8s.io/api/storage/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// TestSchemeDecodesBuiltinCSIDriverVersions guards against the storage/v1 and
+// storage/v1beta1 typed clients silently 404ing/failing to decode because their
+// built-in CSIDriver types were never registered on this clientset's Scheme - unlike
+// the custom csi.storage.k8s.io/v1alpha1 CSIDriver, storage.k8s.io/{v1,v1beta1}
+// CSIDriver only come for free if AddToScheme is actually called for them.
+func TestSchemeDecodesBuiltinCSIDriverVersions(t *testing.T) {
+	cases := []struct {
+		apiVersion string
+		want       interface{}
+	}{
+		{"storage.k8s.io/v1", &storagev1.CSIDriver{}},
+		{"storage.k8s.io/v1beta1", &storagev1beta1.CSIDriver{}},
+	}
+	for _, c := range cases {
+		raw := []byte(`{"apiVersion":"` + c.apiVersion + `","kind":"CSIDriver","metadata":{"name":"my-driver"}}`)
+		obj, _, err := Codecs.UniversalDeserializer().Decode(raw, nil, nil)
+		assert.NoErrorf(t, err, "decoding CSIDriver at %s", c.apiVersion)
+		assert.IsTypef(t, c.want, obj, "decoding CSIDriver at %s", c.apiVersion)
+	}
+}
+
+func TestSchemeRecognizesCustomV1alpha1CSIDriver(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "csi.storage.k8s.io", Version: "v1alpha1", Kind: "CSIDriver"}
+	assert.True(t, Scheme.Recognizes(gvk), "scheme should recognize %s", gvk)
+}
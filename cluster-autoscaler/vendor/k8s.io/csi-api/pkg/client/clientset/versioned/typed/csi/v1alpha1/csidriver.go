@@ -19,6 +19,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,6 +29,7 @@ import (
 	watch "k8s.io/apimachinery/pkg/watch"
 	rest "k8s.io/client-go/rest"
 	v1alpha1 "k8s.io/csi-api/pkg/apis/csi/v1alpha1"
+	applyconfigurationv1alpha1 "k8s.io/csi-api/pkg/client/applyconfiguration/csi/v1alpha1"
 	scheme "k8s.io/csi-api/pkg/client/clientset/versioned/scheme"
 )
 
@@ -35,16 +39,30 @@ type CSIDriversGetter interface {
 	CSIDrivers() CSIDriverInterface
 }
 
-// CSIDriverInterface has methods to work with CSIDriver resources.
+// CSIDriverInterface has methods to work with CSIDriver resources. Every method except
+// Apply, which takes a context.Context itself, also has a WithContext variant that plumbs a context.Context through to the underlying
+// rest.Request, so callers can cancel or set a deadline on long-running
+// List/Watch/DeleteCollection calls; the context-less methods are thin wrappers
+// around their WithContext counterparts that pass context.TODO(), kept for backward
+// compatibility.
 type CSIDriverInterface interface {
 	Create(*v1alpha1.CSIDriver) (*v1alpha1.CSIDriver, error)
+	CreateWithContext(ctx context.Context, cSIDriver *v1alpha1.CSIDriver) (*v1alpha1.CSIDriver, error)
 	Update(*v1alpha1.CSIDriver) (*v1alpha1.CSIDriver, error)
+	UpdateWithContext(ctx context.Context, cSIDriver *v1alpha1.CSIDriver) (*v1alpha1.CSIDriver, error)
 	Delete(name string, options *v1.DeleteOptions) error
+	DeleteWithContext(ctx context.Context, name string, options *v1.DeleteOptions) error
 	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
+	DeleteCollectionWithContext(ctx context.Context, options *v1.DeleteOptions, listOptions v1.ListOptions) error
 	Get(name string, options v1.GetOptions) (*v1alpha1.CSIDriver, error)
+	GetWithContext(ctx context.Context, name string, options v1.GetOptions) (*v1alpha1.CSIDriver, error)
 	List(opts v1.ListOptions) (*v1alpha1.CSIDriverList, error)
+	ListWithContext(ctx context.Context, opts v1.ListOptions) (*v1alpha1.CSIDriverList, error)
 	Watch(opts v1.ListOptions) (watch.Interface, error)
+	WatchWithContext(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.CSIDriver, err error)
+	PatchWithContext(ctx context.Context, name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.CSIDriver, err error)
+	Apply(ctx context.Context, cSIDriver *applyconfigurationv1alpha1.CSIDriverApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.CSIDriver, err error)
 	CSIDriverExpansion
 }
 
@@ -62,18 +80,28 @@ func newCSIDrivers(c *CsiV1alpha1Client) *cSIDrivers {
 
 // Get takes name of the cSIDriver, and returns the corresponding cSIDriver object, and an error if there is any.
 func (c *cSIDrivers) Get(name string, options v1.GetOptions) (result *v1alpha1.CSIDriver, err error) {
+	return c.GetWithContext(context.TODO(), name, options)
+}
+
+// GetWithContext takes name of the cSIDriver, and returns the corresponding cSIDriver object, and an error if there is any.
+func (c *cSIDrivers) GetWithContext(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.CSIDriver, err error) {
 	result = &v1alpha1.CSIDriver{}
 	err = c.client.Get().
 		Resource("csidrivers").
 		Name(name).
 		VersionedParams(&options, scheme.ParameterCodec).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // List takes label and field selectors, and returns the list of CSIDrivers that match those selectors.
 func (c *cSIDrivers) List(opts v1.ListOptions) (result *v1alpha1.CSIDriverList, err error) {
+	return c.ListWithContext(context.TODO(), opts)
+}
+
+// ListWithContext takes label and field selectors, and returns the list of CSIDrivers that match those selectors.
+func (c *cSIDrivers) ListWithContext(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.CSIDriverList, err error) {
 	var timeout time.Duration
 	if opts.TimeoutSeconds != nil {
 		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
@@ -83,13 +111,18 @@ func (c *cSIDrivers) List(opts v1.ListOptions) (result *v1alpha1.CSIDriverList,
 		Resource("csidrivers").
 		VersionedParams(&opts, scheme.ParameterCodec).
 		Timeout(timeout).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Watch returns a watch.Interface that watches the requested cSIDrivers.
 func (c *cSIDrivers) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.WatchWithContext(context.TODO(), opts)
+}
+
+// WatchWithContext returns a watch.Interface that watches the requested cSIDrivers.
+func (c *cSIDrivers) WatchWithContext(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
 	var timeout time.Duration
 	if opts.TimeoutSeconds != nil {
 		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
@@ -99,44 +132,64 @@ func (c *cSIDrivers) Watch(opts v1.ListOptions) (watch.Interface, error) {
 		Resource("csidrivers").
 		VersionedParams(&opts, scheme.ParameterCodec).
 		Timeout(timeout).
-		Watch()
+		Watch(ctx)
 }
 
 // Create takes the representation of a cSIDriver and creates it.  Returns the server's representation of the cSIDriver, and an error, if there is any.
 func (c *cSIDrivers) Create(cSIDriver *v1alpha1.CSIDriver) (result *v1alpha1.CSIDriver, err error) {
+	return c.CreateWithContext(context.TODO(), cSIDriver)
+}
+
+// CreateWithContext takes the representation of a cSIDriver and creates it.  Returns the server's representation of the cSIDriver, and an error, if there is any.
+func (c *cSIDrivers) CreateWithContext(ctx context.Context, cSIDriver *v1alpha1.CSIDriver) (result *v1alpha1.CSIDriver, err error) {
 	result = &v1alpha1.CSIDriver{}
 	err = c.client.Post().
 		Resource("csidrivers").
 		Body(cSIDriver).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Update takes the representation of a cSIDriver and updates it. Returns the server's representation of the cSIDriver, and an error, if there is any.
 func (c *cSIDrivers) Update(cSIDriver *v1alpha1.CSIDriver) (result *v1alpha1.CSIDriver, err error) {
+	return c.UpdateWithContext(context.TODO(), cSIDriver)
+}
+
+// UpdateWithContext takes the representation of a cSIDriver and updates it. Returns the server's representation of the cSIDriver, and an error, if there is any.
+func (c *cSIDrivers) UpdateWithContext(ctx context.Context, cSIDriver *v1alpha1.CSIDriver) (result *v1alpha1.CSIDriver, err error) {
 	result = &v1alpha1.CSIDriver{}
 	err = c.client.Put().
 		Resource("csidrivers").
 		Name(cSIDriver.Name).
 		Body(cSIDriver).
-		Do().
+		Do(ctx).
 		Into(result)
 	return
 }
 
 // Delete takes name of the cSIDriver and deletes it. Returns an error if one occurs.
 func (c *cSIDrivers) Delete(name string, options *v1.DeleteOptions) error {
+	return c.DeleteWithContext(context.TODO(), name, options)
+}
+
+// DeleteWithContext takes name of the cSIDriver and deletes it. Returns an error if one occurs.
+func (c *cSIDrivers) DeleteWithContext(ctx context.Context, name string, options *v1.DeleteOptions) error {
 	return c.client.Delete().
 		Resource("csidrivers").
 		Name(name).
 		Body(options).
-		Do().
+		Do(ctx).
 		Error()
 }
 
 // DeleteCollection deletes a collection of objects.
 func (c *cSIDrivers) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	return c.DeleteCollectionWithContext(context.TODO(), options, listOptions)
+}
+
+// DeleteCollectionWithContext deletes a collection of objects.
+func (c *cSIDrivers) DeleteCollectionWithContext(ctx context.Context, options *v1.DeleteOptions, listOptions v1.ListOptions) error {
 	var timeout time.Duration
 	if listOptions.TimeoutSeconds != nil {
 		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
@@ -146,19 +199,49 @@ func (c *cSIDrivers) DeleteCollection(options *v1.DeleteOptions, listOptions v1.
 		VersionedParams(&listOptions, scheme.ParameterCodec).
 		Timeout(timeout).
 		Body(options).
-		Do().
+		Do(ctx).
 		Error()
 }
 
 // Patch applies the patch and returns the patched cSIDriver.
 func (c *cSIDrivers) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.CSIDriver, err error) {
+	return c.PatchWithContext(context.TODO(), name, pt, data, subresources...)
+}
+
+// PatchWithContext applies the patch and returns the patched cSIDriver.
+func (c *cSIDrivers) PatchWithContext(ctx context.Context, name string, pt types.PatchType, data []byte, subresources ...string) (result *v1alpha1.CSIDriver, err error) {
 	result = &v1alpha1.CSIDriver{}
 	err = c.client.Patch(pt).
 		Resource("csidrivers").
 		SubResource(subresources...).
 		Name(name).
 		Body(data).
-		Do().
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the
+// applied cSIDriver, and an error, if there is any.
+func (c *cSIDrivers) Apply(ctx context.Context, cSIDriver *applyconfigurationv1alpha1.CSIDriverApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.CSIDriver, err error) {
+	if cSIDriver == nil {
+		return nil, fmt.Errorf("cSIDriver provided to Apply must not be nil")
+	}
+	if cSIDriver.Name == nil {
+		return nil, fmt.Errorf("cSIDriver.Name must be provided to Apply")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(cSIDriver)
+	if err != nil {
+		return nil, err
+	}
+	result = &v1alpha1.CSIDriver{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("csidrivers").
+		Name(*cSIDriver.Name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
 		Into(result)
 	return
 }
\ No newline at end of file
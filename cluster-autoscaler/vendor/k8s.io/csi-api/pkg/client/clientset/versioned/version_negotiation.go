@@ -0,0 +1,90 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package versioned
+
+import (
+	"fmt"
+
+	discovery "k8s.io/client-go/discovery"
+	csiv1alpha1 "k8s.io/csi-api/pkg/client/clientset/versioned/typed/csi/v1alpha1"
+	storagev1 "k8s.io/csi-api/pkg/client/clientset/versioned/typed/storage/v1"
+	storagev1beta1 "k8s.io/csi-api/pkg/client/clientset/versioned/typed/storage/v1beta1"
+)
+
+// csiDriverVersionPriority lists the GroupVersions CSIDriver has been served under,
+// newest first, mirroring its graduation from csi.storage.k8s.io/v1alpha1 to
+// storage.k8s.io/v1beta1 and then storage.k8s.io/v1.
+var csiDriverVersionPriority = []string{
+	"storage.k8s.io/v1",
+	"storage.k8s.io/v1beta1",
+	"csi.storage.k8s.io/v1alpha1",
+}
+
+// NegotiatedCSIDriverInterface exposes whichever CSIDriver client version the
+// negotiated GroupVersion resolved to. Exactly one of V1, V1beta1, V1alpha1 is set;
+// callers should branch on Version rather than guess which field is populated.
+type NegotiatedCSIDriverInterface struct {
+	// Version is the negotiated GroupVersion, e.g. "storage.k8s.io/v1".
+	Version string
+
+	V1       storagev1.CSIDriverInterface
+	V1beta1  storagev1beta1.CSIDriverInterface
+	V1alpha1 csiv1alpha1.CSIDriverInterface
+}
+
+// NewNegotiatedCSIDriverClient picks the newest CSIDriver GroupVersion the API
+// server serves (preferring storage.k8s.io/v1, then storage.k8s.io/v1beta1, then
+// csi.storage.k8s.io/v1alpha1) and returns a client bound to it, so callers don't
+// have to hard-code v1alpha1.
+//
+// Nothing in this tree consults CSIDriver yet - this is infrastructure for whichever
+// autoscaler component picks that up next, not a drop-in replacement for an existing
+// hard-coded v1alpha1 caller.
+func NewNegotiatedCSIDriverClient(cs *Clientset, discoveryClient discovery.DiscoveryInterface) (*NegotiatedCSIDriverInterface, error) {
+	for _, gv := range csiDriverVersionPriority {
+		if !serverServesCSIDrivers(discoveryClient, gv) {
+			continue
+		}
+		switch gv {
+		case "storage.k8s.io/v1":
+			return &NegotiatedCSIDriverInterface{Version: gv, V1: cs.StorageV1().CSIDrivers()}, nil
+		case "storage.k8s.io/v1beta1":
+			return &NegotiatedCSIDriverInterface{Version: gv, V1beta1: cs.StorageV1beta1().CSIDrivers()}, nil
+		case "csi.storage.k8s.io/v1alpha1":
+			return &NegotiatedCSIDriverInterface{Version: gv, V1alpha1: cs.CsiV1alpha1().CSIDrivers()}, nil
+		}
+	}
+	return nil, fmt.Errorf("server does not serve any known CSIDriver version (tried %v)", csiDriverVersionPriority)
+}
+
+// serverServesCSIDrivers reports whether the API server not only serves the
+// GroupVersion gv, but actually serves a "csidrivers" resource under it. A
+// GroupVersion like storage.k8s.io/v1 can be served long before CSIDriver itself
+// graduates into it (StorageClass and VolumeAttachment got there first), so checking
+// ServerGroups() alone would pick a version whose CSIDriver calls all 404.
+func serverServesCSIDrivers(discoveryClient discovery.DiscoveryInterface, gv string) bool {
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(gv)
+	if err != nil {
+		return false
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == "csidrivers" {
+			return true
+		}
+	}
+	return false
+}